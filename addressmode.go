@@ -0,0 +1,31 @@
+package pixel
+
+// AddressMode controls how a Picture is sampled when Triangles reference picture coordinates
+// outside its Bounds.
+type AddressMode int
+
+const (
+	// AddressClampToZero returns the zero value (transparent black) for reads outside Bounds.
+	// This is the behavior every Picture has always had.
+	AddressClampToZero AddressMode = iota
+
+	// AddressClampToEdge clamps out-of-bounds reads to the nearest edge of Bounds.
+	AddressClampToEdge
+
+	// AddressRepeat wraps out-of-bounds reads around Bounds, tiling the Picture.
+	AddressRepeat
+
+	// AddressMirroredRepeat wraps out-of-bounds reads around Bounds like AddressRepeat, but
+	// mirrors every other tile.
+	AddressMirroredRepeat
+)
+
+// PictureAddress is a TargetPicture extension for TargetPictures capable of sampling outside
+// their Bounds using an AddressMode, instead of the default AddressClampToZero behavior.
+type PictureAddress interface {
+	TargetPicture
+
+	// SetAddress sets the AddressMode used when this TargetPicture is sampled outside its
+	// Bounds.
+	SetAddress(AddressMode)
+}
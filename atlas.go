@@ -0,0 +1,234 @@
+package pixel
+
+import "fmt"
+
+// AtlasPageFactory creates a blank atlas page for an AtlasBatch: a Target the AtlasBatch can
+// stamp incoming Pictures onto, paired with that same backing surface viewed as a Picture so the
+// packed page can later be drawn as a TargetPicture of whichever Target the AtlasBatch itself is
+// drawn onto.
+type AtlasPageFactory func() (Target, Picture)
+
+// AtlasBatch is like Batch, but accepts Pictures of any Original (instead of Batch's single fixed
+// Picture) by packing them into one or more internal atlas pages with a shelf packer. Adjacent
+// tiles drawn from many distinct source Pictures this way collapse into as few GL draw calls as
+// they fit pages, instead of one draw call per distinct Picture.
+//
+// Like Batch, removing individual Pictures from an AtlasBatch isn't supported; call Clear to
+// start over.
+type AtlasBatch struct {
+	*Batch
+
+	pageSize float64
+	newPage  AtlasPageFactory
+
+	pages []*atlasPage
+	slots map[Picture]atlasSlot
+}
+
+// NewAtlasBatch creates an empty AtlasBatch. pageSize is the edge length of a (square) atlas
+// page; newPage is called to create a blank page whenever the existing ones are full.
+func NewAtlasBatch(container Triangles, pageSize float64, newPage AtlasPageFactory) *AtlasBatch {
+	return &AtlasBatch{
+		Batch:    NewBatch(container, nil),
+		pageSize: pageSize,
+		newPage:  newPage,
+		slots:    make(map[Picture]atlasSlot),
+	}
+}
+
+// PageCount returns the number of atlas pages currently allocated.
+func (ab *AtlasBatch) PageCount() int {
+	return len(ab.pages)
+}
+
+// Clear removes all objects and evicts every packed Picture from the AtlasBatch, releasing its
+// pages so a long-running app can start packing fresh ones.
+func (ab *AtlasBatch) Clear() {
+	ab.Batch.Clear()
+	ab.pages = nil
+	ab.slots = make(map[Picture]atlasSlot)
+}
+
+// MakePicture packs p into an atlas page, reusing the existing slot for p.Original() if there is
+// one, and returns a TargetPicture that rewrites incoming vertex UVs from p's coordinate space
+// into that page's.
+func (ab *AtlasBatch) MakePicture(p Picture) TargetPicture {
+	orig := p.Original()
+
+	slot, ok := ab.slots[orig]
+	if !ok {
+		var err error
+		slot, err = ab.insert(orig)
+		if err != nil {
+			panic(fmt.Errorf("AtlasBatch.MakePicture: %w", err))
+		}
+		ab.slots[orig] = slot
+	}
+
+	page := ab.pages[slot.page]
+	offset := slot.rect.Min.Sub(orig.Bounds().Min)
+
+	return &atlasPicture{
+		Picture: page.pic.Slice(p.Bounds().Moved(offset)),
+		bounds:  p.Bounds(),
+		bp:      ab.Batch.MakePicture(page.pic).(*batchPicture),
+		offset:  offset,
+		orig:    orig,
+	}
+}
+
+// insert finds (allocating a new page if necessary) room for orig and stamps its pixel data onto
+// the chosen page.
+func (ab *AtlasBatch) insert(orig Picture) (atlasSlot, error) {
+	bounds := orig.Bounds()
+	if bounds.W() > ab.pageSize || bounds.H() > ab.pageSize {
+		return atlasSlot{}, fmt.Errorf("picture of size %v does not fit a page of size %v", bounds.Size(), ab.pageSize)
+	}
+
+	for i, page := range ab.pages {
+		if rect, ok := page.alloc(bounds.W(), bounds.H(), ab.pageSize); ok {
+			ab.stamp(page, orig, rect)
+			return atlasSlot{page: i, rect: rect}, nil
+		}
+	}
+
+	target, pic := ab.newPage()
+	page := &atlasPage{target: target, pic: pic}
+	ab.pages = append(ab.pages, page)
+
+	rect, ok := page.alloc(bounds.W(), bounds.H(), ab.pageSize)
+	if !ok {
+		return atlasSlot{}, fmt.Errorf("picture of size %v does not fit a fresh page of size %v", bounds.Size(), ab.pageSize)
+	}
+	ab.stamp(page, orig, rect)
+	return atlasSlot{page: len(ab.pages) - 1, rect: rect}, nil
+}
+
+// stamp draws orig's pixel data onto page at rect using the page's own Target.
+func (ab *AtlasBatch) stamp(page *atlasPage, orig Picture, rect Rect) {
+	tp := page.target.MakePicture(orig)
+	tt := page.target.MakeTriangles(quadTriangles(rect, orig.Bounds()))
+	tp.Draw(tt)
+}
+
+// quadTriangles builds the 6 vertices (two triangles) of an opaque, full-intensity textured quad
+// that covers dst in Position space while sampling src one-to-one in Picture space. This is
+// exactly what AtlasBatch.stamp needs to copy a Picture's pixels onto a page at a given rect.
+func quadTriangles(dst, src Rect) *TrianglesData {
+	td := MakeTrianglesData(6)
+
+	dstCorners := [4]Vec{
+		dst.Min,
+		V(dst.Max.X(), dst.Min.Y()),
+		dst.Max,
+		V(dst.Min.X(), dst.Max.Y()),
+	}
+	srcCorners := [4]Vec{
+		src.Min,
+		V(src.Max.X(), src.Min.Y()),
+		src.Max,
+		V(src.Min.X(), src.Max.Y()),
+	}
+
+	for i, c := range [6]int{0, 1, 2, 0, 2, 3} {
+		(*td)[i].Position = dstCorners[c]
+		(*td)[i].Color = NRGBA{R: 1, G: 1, B: 1, A: 1}
+		(*td)[i].Picture = srcCorners[c]
+		(*td)[i].Intensity = 1
+	}
+
+	return td
+}
+
+// atlasSlot locates a packed Picture's sub-region within one of an AtlasBatch's pages.
+type atlasSlot struct {
+	page int
+	rect Rect
+}
+
+// atlasPage is one internal atlas texture, along with the shelf packer tracking its free space.
+type atlasPage struct {
+	target Target
+	pic    Picture
+
+	shelves []atlasShelf
+}
+
+// atlasShelf is one row of a shelf packer: a horizontal strip of height h starting at y, filled
+// with rectangles left to right up to x.
+type atlasShelf struct {
+	y, h, x float64
+}
+
+// alloc finds room for a w*h rectangle on the page, adding a new shelf if no existing one fits.
+func (p *atlasPage) alloc(w, h, pageSize float64) (Rect, bool) {
+	for i := range p.shelves {
+		s := &p.shelves[i]
+		if h <= s.h && s.x+w <= pageSize {
+			rect := R(s.x, s.y, s.x+w, s.y+h)
+			s.x += w
+			return rect, true
+		}
+	}
+
+	if w > pageSize {
+		return Rect{}, false
+	}
+
+	y := 0.0
+	if n := len(p.shelves); n > 0 {
+		last := p.shelves[n-1]
+		y = last.y + last.h
+	}
+	if y+h > pageSize {
+		return Rect{}, false
+	}
+
+	p.shelves = append(p.shelves, atlasShelf{y: y, h: h, x: w})
+	return R(0, y, w, y+h), true
+}
+
+// atlasPicture is the TargetPicture an AtlasBatch hands out: it draws like a batchPicture bound
+// to the atlas page's backing Picture, but offsets every vertex's Picture coordinate from the
+// original Picture's space into the page's.
+//
+// Like batchPicture, atlasPicture's public coordinate space (Bounds, Slice) always matches the
+// original Picture it was packed from, never the page it landed on — offset is applied exactly
+// once, in draw, when translating a vertex's Picture coordinate onto the page.
+type atlasPicture struct {
+	Picture
+
+	bounds Rect
+	bp     *batchPicture
+	offset Vec
+	orig   Picture // the real upstream Picture this was packed from, for Original()
+}
+
+func (ap *atlasPicture) Bounds() Rect {
+	return ap.bounds
+}
+
+func (ap *atlasPicture) Slice(r Rect) Picture {
+	return &atlasPicture{
+		Picture: ap.Picture.Slice(r.Moved(ap.offset)),
+		bounds:  r,
+		bp:      ap.bp,
+		offset:  ap.offset,
+		orig:    ap.orig,
+	}
+}
+
+// Original returns the Picture this atlasPicture was packed from — not the page's own Picture,
+// which is shared by every Picture packed into that page and would otherwise make them
+// indistinguishable to any cache keyed on Original (including AtlasBatch's own slots map).
+func (ap *atlasPicture) Original() Picture {
+	return ap.orig
+}
+
+func (ap *atlasPicture) Draw(t TargetTriangles) {
+	bt := t.(*batchTriangles)
+	if ap.bp.b != bt.b {
+		panic(fmt.Errorf("%T.Draw: TargetTriangles generated by different Batch", ap))
+	}
+	bt.draw(ap.bp, ap.offset)
+}
@@ -0,0 +1,67 @@
+package pixel
+
+import "testing"
+
+func TestAtlasPageAllocFitsOnSameShelf(t *testing.T) {
+	p := &atlasPage{}
+
+	r1, ok := p.alloc(10, 20, 100)
+	if !ok {
+		t.Fatalf("alloc(10, 20, 100) = _, false, want true")
+	}
+	if want := R(0, 0, 10, 20); r1 != want {
+		t.Fatalf("first alloc = %v, want %v", r1, want)
+	}
+
+	r2, ok := p.alloc(15, 20, 100)
+	if !ok {
+		t.Fatalf("alloc(15, 20, 100) = _, false, want true")
+	}
+	if want := R(10, 0, 25, 20); r2 != want {
+		t.Fatalf("second alloc = %v, want %v", r2, want)
+	}
+}
+
+func TestAtlasPageAllocStartsNewShelf(t *testing.T) {
+	p := &atlasPage{}
+
+	if _, ok := p.alloc(10, 20, 100); !ok {
+		t.Fatalf("first alloc should fit")
+	}
+
+	r, ok := p.alloc(10, 30, 100)
+	if !ok {
+		t.Fatalf("alloc(10, 30, 100) = _, false, want true")
+	}
+	if want := R(0, 20, 10, 50); r != want {
+		t.Fatalf("new-shelf alloc = %v, want %v", r, want)
+	}
+}
+
+func TestAtlasPageAllocRejectsOversizedRect(t *testing.T) {
+	p := &atlasPage{}
+
+	if _, ok := p.alloc(200, 10, 100); ok {
+		t.Fatalf("alloc wider than pageSize should fail")
+	}
+	if _, ok := p.alloc(10, 200, 100); ok {
+		t.Fatalf("alloc taller than pageSize should fail")
+	}
+}
+
+func TestAtlasPageAllocRejectsWhenPageIsFull(t *testing.T) {
+	p := &atlasPage{}
+
+	// Two 50x60 rects exactly fill the first (and only fittable) shelf's width; a third no
+	// longer fits that shelf, and a second shelf of height 60 doesn't fit below it in a
+	// pageSize-100 page, so it must fail rather than silently dropping the rect.
+	if _, ok := p.alloc(50, 60, 100); !ok {
+		t.Fatalf("first alloc should fit")
+	}
+	if _, ok := p.alloc(50, 60, 100); !ok {
+		t.Fatalf("second alloc should fit the remaining shelf width")
+	}
+	if _, ok := p.alloc(50, 60, 100); ok {
+		t.Fatalf("alloc on a full page should fail")
+	}
+}
@@ -13,13 +13,32 @@ import (
 // To put an object into a Batch, just draw it onto it:
 //   object.Draw(batch)
 type Batch struct {
-	cont Drawer
+	pic  Picture
+	runs []*batchRun
 
-	mat Matrix
-	col NRGBA
+	mat    Matrix
+	colorM ColorM
+	shader *Shader
+	comp   CompositeMode
+	addr   AddressMode
+	filter Filter
 }
 
 var _ BasicTarget = (*Batch)(nil)
+var _ TargetShader = (*Batch)(nil)
+
+// batchRun is a contiguous span of accumulated Triangles that share the same GPU draw state
+// (Picture, CompositeMode, Shader and its uniform revision, AddressMode and Filter at the time
+// the run was started). Batch.Draw replays the runs in the order they were started.
+type batchRun struct {
+	Drawer
+
+	comp      CompositeMode
+	shader    *Shader
+	shaderRev uint64
+	addr      AddressMode
+	filter    Filter
+}
 
 // NewBatch creates an empty Batch with the specified Picture and container.
 //
@@ -28,20 +47,98 @@ var _ BasicTarget = (*Batch)(nil)
 //
 // Note, that if the container does not support TrianglesColor, color masking will not work.
 func NewBatch(container Triangles, pic Picture) *Batch {
-	return &Batch{
-		cont: Drawer{Triangles: container, Picture: pic},
+	b := &Batch{
+		pic:    pic,
+		colorM: IdentityColorM(),
 	}
+	b.runs = []*batchRun{{Drawer: Drawer{Triangles: container, Picture: pic}}}
+	return b
+}
+
+// newContainer returns an empty Triangles of the same concrete type as the container the Batch
+// was created with, for starting a new batchRun.
+func (b *Batch) newContainer() Triangles {
+	t := b.runs[0].Triangles.Copy()
+	t.SetLen(0)
+	return t
+}
+
+// shaderRev returns s's current uniform/aux revision, a cheap stand-in for deep-comparing its
+// uniforms. A nil Shader always has revision 0.
+func shaderRev(s *Shader) uint64 {
+	if s == nil {
+		return 0
+	}
+	return s.rev
+}
+
+// currentRun returns the batchRun that a draw happening right now, using pic as its Picture,
+// should accumulate into, starting a new one if pic or the Batch's current
+// CompositeMode/Shader/AddressMode/Filter differ from the last run's (an empty last run is reused
+// in place rather than left dangling).
+//
+// Threading pic through explicitly (rather than always using b.pic) is what lets an AtlasBatch
+// split runs across atlas pages: each atlasPicture.Draw passes the Picture of whichever page it
+// was packed into, so a run only ever accumulates Triangles that sample a single Picture.
+func (b *Batch) currentRun(pic Picture) *batchRun {
+	rev := shaderRev(b.shader)
+	last := b.runs[len(b.runs)-1]
+
+	switch {
+	case last.Picture == pic && last.comp == b.comp && last.shader == b.shader && last.shaderRev == rev &&
+		last.addr == b.addr && last.filter == b.filter:
+		return last
+	case last.Triangles.Len() == 0:
+		last.Picture = pic
+		last.comp, last.shader, last.shaderRev, last.addr, last.filter = b.comp, b.shader, rev, b.addr, b.filter
+		return last
+	}
+
+	run := &batchRun{
+		Drawer:    Drawer{Triangles: b.newContainer(), Picture: pic},
+		comp:      b.comp,
+		shader:    b.shader,
+		shaderRev: rev,
+		addr:      b.addr,
+		filter:    b.filter,
+	}
+	b.runs = append(b.runs, run)
+	return run
 }
 
 // Clear removes all objects from the Batch.
 func (b *Batch) Clear() {
-	b.cont.Triangles.SetLen(0)
-	b.cont.Dirty()
+	first := b.runs[0]
+	first.Triangles.SetLen(0)
+	first.Picture = b.pic
+	first.comp, first.shader, first.shaderRev = CompositeModeSourceOver, nil, 0
+	first.addr, first.filter = AddressClampToZero, FilterNearest
+	first.Dirty()
+	b.runs = b.runs[:1]
 }
 
-// Draw draws all objects that are currently in the Batch onto another Target.
+// Draw draws all objects that are currently in the Batch onto another Target, replaying its runs
+// in the order they were accumulated and applying each run's CompositeMode/Shader/AddressMode/
+// Filter first, if t (or its bound Picture) supports them.
 func (b *Batch) Draw(t Target) {
-	b.cont.Draw(t)
+	for _, run := range b.runs {
+		if run.Triangles.Len() == 0 {
+			continue
+		}
+		if bt, ok := t.(BasicTarget); ok {
+			bt.SetCompositeMode(run.comp)
+		}
+		if ts, ok := t.(TargetShader); ok {
+			ts.SetShader(run.shader)
+		}
+		if ap, ok := run.Picture.(PictureAddress); ok {
+			ap.SetAddress(run.addr)
+		}
+		if fp, ok := run.Picture.(PictureFilter); ok {
+			fp.SetFilter(run.filter)
+		}
+		run.Draw(t)
+	}
 }
 
 // SetMatrix sets a Matrix that every point will be projected by.
@@ -52,10 +149,46 @@ func (b *Batch) SetMatrix(m Matrix) {
 // SetColorMask sets a mask color used in the following draws onto the Batch.
 func (b *Batch) SetColorMask(c color.Color) {
 	if c == nil {
-		b.col = NRGBA{1, 1, 1, 1}
+		b.colorM = IdentityColorM()
 		return
 	}
-	b.col = NRGBAModel.Convert(c).(NRGBA)
+	b.colorM = colorMFromNRGBA(NRGBAModel.Convert(c).(NRGBA))
+}
+
+// SetColorM sets a ColorM used in the following draws onto the Batch, replacing whatever was set
+// via SetColorMask or a previous SetColorM.
+func (b *Batch) SetColorM(m ColorM) {
+	b.colorM = m
+}
+
+// SetShader sets the Shader used for all subsequent draws onto the Batch, replacing the default
+// fixed-function Intensity*Picture + (1-Intensity)*Color blend. Passing nil restores the default.
+//
+// Changing the Shader (or its uniforms) between draws starts a new run, so objects drawn with
+// different Shaders or uniform values inside the same Batch are kept separate and replayed onto
+// the target Target in the order they were drawn.
+func (b *Batch) SetShader(s *Shader) {
+	b.shader = s
+}
+
+// SetCompositeMode sets the CompositeMode used for all subsequent draws onto the Batch. Like
+// SetShader, changing it between draws starts a new run.
+func (b *Batch) SetCompositeMode(c CompositeMode) {
+	b.comp = c
+}
+
+// SetAddress sets the AddressMode used for all subsequent draws onto the Batch, passed through to
+// the Batch's Picture at Draw time if it implements PictureAddress. Like SetShader, changing it
+// between draws starts a new run.
+func (b *Batch) SetAddress(a AddressMode) {
+	b.addr = a
+}
+
+// SetFilter sets the Filter used for all subsequent draws onto the Batch, passed through to the
+// Batch's Picture at Draw time if it implements PictureFilter. Like SetShader, changing it between
+// draws starts a new run.
+func (b *Batch) SetFilter(f Filter) {
+	b.filter = f
 }
 
 // MakeTriangles returns a specialized copy of the provided Triangles that draws onto this Batch.
@@ -88,7 +221,7 @@ type batchTriangles struct {
 	b *Batch
 }
 
-func (bt *batchTriangles) draw(bp *batchPicture) {
+func (bt *batchTriangles) draw(bp *batchPicture, picOffset Vec) {
 	for i := range *bt.trans {
 		transPos := mgl64.Mat3(bt.b.mat).Mul3x1(mgl64.Vec3{
 			(*bt.orig)[i].Position.X(),
@@ -96,8 +229,8 @@ func (bt *batchTriangles) draw(bp *batchPicture) {
 			1,
 		})
 		(*bt.trans)[i].Position = V(float64(transPos.X()), float64(transPos.Y()))
-		(*bt.trans)[i].Color = (*bt.orig)[i].Color.Mul(bt.b.col)
-		(*bt.trans)[i].Picture = (*bt.orig)[i].Picture
+		(*bt.trans)[i].Color = bt.b.colorM.Apply((*bt.orig)[i].Color)
+		(*bt.trans)[i].Picture = (*bt.orig)[i].Picture.Add(picOffset)
 		(*bt.trans)[i].Intensity = (*bt.orig)[i].Intensity
 		if bp == nil {
 			(*bt.trans)[i].Intensity = 0
@@ -106,14 +239,22 @@ func (bt *batchTriangles) draw(bp *batchPicture) {
 
 	bt.Triangles.Update(bt.trans)
 
-	cont := bt.b.cont.Triangles
+	// Slices of the Batch's own Picture share its runs; only a Picture with a different Original
+	// starts a new one.
+	pic := bt.b.pic
+	if bp != nil && (pic == nil || bp.Picture.Original() != pic.Original()) {
+		pic = bp.Picture
+	}
+
+	run := bt.b.currentRun(pic)
+	cont := run.Triangles
 	cont.SetLen(cont.Len() + bt.Triangles.Len())
 	cont.Slice(cont.Len()-bt.Triangles.Len(), cont.Len()).Update(bt.Triangles)
-	bt.b.cont.Dirty()
+	run.Dirty()
 }
 
 func (bt *batchTriangles) Draw() {
-	bt.draw(nil)
+	bt.draw(nil, V(0, 0))
 }
 
 type batchPicture struct {
@@ -139,5 +280,5 @@ func (bp *batchPicture) Draw(t TargetTriangles) {
 	if bp.b != bt.b {
 		panic(fmt.Errorf("%T.Draw: TargetTriangles generated by different Batch", bp))
 	}
-	bt.draw(bp)
+	bt.draw(bp, V(0, 0))
 }
@@ -0,0 +1,90 @@
+package pixel
+
+import "math"
+
+// ColorM is a 4x5 affine transform applied to a color's (R, G, B, A) components: the first four
+// columns are a 4x4 linear matrix, the fifth column is an additive bias.
+//
+// Applying a ColorM to an NRGBA c computes, for each output channel i:
+//   out[i] = m[i][0]*c.R + m[i][1]*c.G + m[i][2]*c.B + m[i][3]*c.A + m[i][4]
+//
+// This is the same model as ebiten's affine.ColorM and unlocks tinting, grayscale/sepia, hue
+// rotation and channel swizzles without writing a Shader.
+type ColorM [4][5]float64
+
+// IdentityColorM returns a ColorM that leaves colors unchanged.
+func IdentityColorM() ColorM {
+	return ColorM{
+		{1, 0, 0, 0, 0},
+		{0, 1, 0, 0, 0},
+		{0, 0, 1, 0, 0},
+		{0, 0, 0, 1, 0},
+	}
+}
+
+// Concat returns the ColorM equivalent to applying m first, then n.
+func (m ColorM) Concat(n ColorM) ColorM {
+	var r ColorM
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 4; j++ {
+			sum := 0.0
+			for k := 0; k < 4; k++ {
+				sum += n[i][k] * m[k][j]
+			}
+			r[i][j] = sum
+		}
+		r[i][4] = n[i][4]
+		for k := 0; k < 4; k++ {
+			r[i][4] += n[i][k] * m[k][4]
+		}
+	}
+	return r
+}
+
+// Scale returns a copy of m with a diagonal (r, g, b, a) scale applied after it.
+func (m ColorM) Scale(r, g, b, a float64) ColorM {
+	scale := IdentityColorM()
+	scale[0][0], scale[1][1], scale[2][2], scale[3][3] = r, g, b, a
+	return m.Concat(scale)
+}
+
+// Translate returns a copy of m with an additive (r, g, b, a) bias applied after it.
+func (m ColorM) Translate(r, g, b, a float64) ColorM {
+	t := IdentityColorM()
+	t[0][4], t[1][4], t[2][4], t[3][4] = r, g, b, a
+	return m.Concat(t)
+}
+
+// RotateHue returns a copy of m with a hue rotation by theta radians (in YIQ space) applied
+// after it.
+func (m ColorM) RotateHue(theta float64) ColorM {
+	sin, cos := math.Sin(theta), math.Cos(theta)
+	rotate := ColorM{
+		{0.299 + 0.701*cos + 0.168*sin, 0.587 - 0.587*cos + 0.330*sin, 0.114 - 0.114*cos - 0.497*sin, 0, 0},
+		{0.299 - 0.299*cos - 0.328*sin, 0.587 + 0.413*cos + 0.035*sin, 0.114 - 0.114*cos + 0.292*sin, 0, 0},
+		{0.299 - 0.299*cos + 1.250*sin, 0.587 - 0.587*cos - 1.050*sin, 0.114 + 0.886*cos - 0.203*sin, 0, 0},
+		{0, 0, 0, 1, 0},
+	}
+	return m.Concat(rotate)
+}
+
+// Apply applies the ColorM to c and returns the resulting color.
+func (m ColorM) Apply(c NRGBA) NRGBA {
+	in := [4]float64{c.R, c.G, c.B, c.A}
+	var out [4]float64
+	for i := 0; i < 4; i++ {
+		out[i] = m[i][4]
+		for j := 0; j < 4; j++ {
+			out[i] += m[i][j] * in[j]
+		}
+	}
+	return NRGBA{R: out[0], G: out[1], B: out[2], A: out[3]}
+}
+
+// colorMFromNRGBA returns the diagonal ColorM that multiplies each channel by the matching
+// channel of c, matching the behavior of the legacy SetColorMask.
+func colorMFromNRGBA(c NRGBA) ColorM {
+	m := IdentityColorM()
+	m[0][0], m[1][1], m[2][2], m[3][3] = c.R, c.G, c.B, c.A
+	return m
+}
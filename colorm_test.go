@@ -0,0 +1,68 @@
+package pixel
+
+import (
+	"math"
+	"testing"
+)
+
+func approxEqualColor(a, b NRGBA) bool {
+	const eps = 1e-9
+	return math.Abs(a.R-b.R) < eps && math.Abs(a.G-b.G) < eps &&
+		math.Abs(a.B-b.B) < eps && math.Abs(a.A-b.A) < eps
+}
+
+func TestIdentityColorMApply(t *testing.T) {
+	c := NRGBA{R: 0.2, G: 0.4, B: 0.6, A: 0.8}
+	got := IdentityColorM().Apply(c)
+	if !approxEqualColor(got, c) {
+		t.Fatalf("IdentityColorM().Apply(%v) = %v, want %v", c, got, c)
+	}
+}
+
+func TestColorMScale(t *testing.T) {
+	c := NRGBA{R: 1, G: 1, B: 1, A: 1}
+	m := IdentityColorM().Scale(0.5, 0.25, 0, 1)
+	got := m.Apply(c)
+	want := NRGBA{R: 0.5, G: 0.25, B: 0, A: 1}
+	if !approxEqualColor(got, want) {
+		t.Fatalf("Scale(0.5, 0.25, 0, 1).Apply(%v) = %v, want %v", c, got, want)
+	}
+}
+
+func TestColorMTranslate(t *testing.T) {
+	c := NRGBA{R: 0, G: 0, B: 0, A: 0}
+	m := IdentityColorM().Translate(0.1, 0.2, 0.3, 0)
+	got := m.Apply(c)
+	want := NRGBA{R: 0.1, G: 0.2, B: 0.3, A: 0}
+	if !approxEqualColor(got, want) {
+		t.Fatalf("Translate(0.1, 0.2, 0.3, 0).Apply(%v) = %v, want %v", c, got, want)
+	}
+}
+
+func TestColorMConcatOrder(t *testing.T) {
+	c := NRGBA{R: 1, G: 1, B: 1, A: 1}
+	scaleThenTranslate := IdentityColorM().Scale(0.5, 0.5, 0.5, 1).Translate(0.1, 0.1, 0.1, 0)
+	got := scaleThenTranslate.Apply(c)
+	want := NRGBA{R: 0.6, G: 0.6, B: 0.6, A: 1}
+	if !approxEqualColor(got, want) {
+		t.Fatalf("Scale then Translate applied to %v = %v, want %v", c, got, want)
+	}
+}
+
+func TestColorMRotateHueIdentity(t *testing.T) {
+	c := NRGBA{R: 0.3, G: 0.6, B: 0.9, A: 1}
+	got := IdentityColorM().RotateHue(0).Apply(c)
+	if !approxEqualColor(got, c) {
+		t.Fatalf("RotateHue(0).Apply(%v) = %v, want %v (no-op)", c, got, c)
+	}
+}
+
+func TestColorMFromNRGBAMatchesColorMask(t *testing.T) {
+	mask := NRGBA{R: 0.5, G: 1, B: 0, A: 0.75}
+	c := NRGBA{R: 0.8, G: 0.4, B: 0.2, A: 1}
+	got := colorMFromNRGBA(mask).Apply(c)
+	want := NRGBA{R: c.R * mask.R, G: c.G * mask.G, B: c.B * mask.B, A: c.A * mask.A}
+	if !approxEqualColor(got, want) {
+		t.Fatalf("colorMFromNRGBA(%v).Apply(%v) = %v, want %v", mask, c, got, want)
+	}
+}
@@ -0,0 +1,41 @@
+package pixel
+
+// CompositeMode describes how newly drawn pixels are combined with whatever is already in a
+// Target, mirroring the Porter-Duff operators and blend modes of ebiten's driver.CompositeMode
+// and image/draw's Op.
+type CompositeMode int
+
+const (
+	// CompositeModeSourceOver is the default "over" operator: the source is blended on top of
+	// the destination using the source's alpha. This is the blend Batch and the rest of pixel
+	// have always used.
+	CompositeModeSourceOver CompositeMode = iota
+
+	// CompositeModeSource replaces the destination with the source, ignoring what's already
+	// there, but still writes transparent pixels where the source is transparent (see
+	// CompositeModeCopy for "write only where the source is opaque").
+	CompositeModeSource
+
+	// CompositeModeLighter adds the source on top of the destination (additive blending).
+	// Useful for particles, glow and light accumulation.
+	CompositeModeLighter
+
+	// CompositeModeMultiply multiplies the source and destination channels together.
+	CompositeModeMultiply
+
+	// CompositeModeScreen is the inverse-multiply blend: lightens the destination by the source.
+	CompositeModeScreen
+
+	// CompositeModeDestinationOut erases the destination wherever the source is opaque.
+	CompositeModeDestinationOut
+
+	// CompositeModeXor keeps only the non-overlapping parts of the source and destination.
+	CompositeModeXor
+
+	// CompositeModeCopy writes the source directly into the destination, including its alpha,
+	// wherever the source is opaque.
+	CompositeModeCopy
+
+	// CompositeModeClear clears the destination to transparent wherever the source is opaque.
+	CompositeModeClear
+)
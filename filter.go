@@ -0,0 +1,26 @@
+package pixel
+
+// Filter controls how a Picture is sampled between texel centers.
+type Filter int
+
+const (
+	// FilterNearest samples the single nearest texel. The right choice for pixel-art sprites.
+	FilterNearest Filter = iota
+
+	// FilterLinear interpolates between neighboring texels. Needed for scaled or rotated
+	// photographic sprites to avoid aliasing; combine with SetMipmap for minification.
+	FilterLinear
+)
+
+// PictureFilter is a TargetPicture extension for TargetPictures capable of choosing their own
+// sampler Filter and mipmap behavior, rather than having it decided globally by the Target.
+type PictureFilter interface {
+	TargetPicture
+
+	// SetFilter sets the Filter used when sampling this TargetPicture.
+	SetFilter(Filter)
+
+	// SetMipmap enables or disables mipmap generation for this TargetPicture. Mipmapping only
+	// has an effect when the Filter is FilterLinear.
+	SetMipmap(bool)
+}
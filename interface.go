@@ -34,7 +34,35 @@ type BasicTarget interface {
 
 	// SetColorMask sets a color that will be multiplied with the TrianglesColor property of all
 	// Triangles.
+	//
+	// SetColorMask is equivalent to calling SetColorM with the diagonal ColorM constructed from
+	// c; it's kept around for the common case of simple tinting.
 	SetColorMask(color.Color)
+
+	// SetColorM sets a ColorM, a full affine color transform, that will be applied to the
+	// TrianglesColor property of all Triangles in place of the simpler SetColorMask multiply.
+	SetColorM(ColorM)
+
+	// SetCompositeMode sets the CompositeMode used to combine all subsequently drawn pixels with
+	// whatever is already in the Target.
+	SetCompositeMode(CompositeMode)
+
+	// SetFilter sets the Filter used to sample the bound Picture for all subsequent draws,
+	// passed through to that Picture if it implements PictureFilter.
+	SetFilter(Filter)
+}
+
+// TargetShader is a BasicTarget extension for Targets capable of using a custom Shader in place
+// of their fixed-function Intensity*Picture + (1-Intensity)*Color blend.
+//
+// As with the rest of pixel's optional Target capabilities, not every Target implements this;
+// type-assert a Target against TargetShader before using it.
+type TargetShader interface {
+	BasicTarget
+
+	// SetShader sets the Shader used for all subsequent draws. Passing nil switches back to the
+	// Target's default fixed-function blending.
+	SetShader(*Shader)
 }
 
 // Triangles represents a list of vertices, where each three vertices form a triangle. (First,
@@ -0,0 +1,90 @@
+package pixel
+
+import "fmt"
+
+// Uniforms holds the named values bound to a Shader, such as floats, Vecs (vec2), [3]float64
+// (vec3), [4]float64 (vec4), mgl64.Mat3, or int.
+//
+// Which types and names a Shader actually accepts depends on its source; the Target using the
+// Shader is responsible for converting a Uniforms value into whatever its shading language
+// expects.
+type Uniforms map[string]interface{}
+
+// Shader is a custom fragment program that a TargetShader can use in place of the fixed-function
+// Intensity*Picture + (1-Intensity)*Color blend applied to each Triangles vertex.
+//
+// A Shader is created from source text (typically GLSL, though this is opaque to the pixel
+// package and entirely up to the TargetShader consuming it). Beside its source, a Shader carries
+// a set of uniform values and a list of auxiliary Pictures bound to extra sampler slots, so a
+// single Shader instance can be reused across draws with different inputs.
+type Shader struct {
+	vertexSrc, fragmentSrc string
+	uniforms               Uniforms
+	aux                    []AuxPicture
+
+	// rev is bumped on every SetUniform/SetAux call, so callers that need to notice a change
+	// (e.g. Batch's run splitting) can compare a cheap uint64 instead of deep-comparing uniforms.
+	rev uint64
+}
+
+// AuxPicture pairs an auxiliary Picture bound to a Shader with the source region (in that
+// Picture's own coordinate space, i.e. its Bounds) a backend should sample it through. Carrying
+// the region alongside the Picture lets a Shader sample a sliced sub-Picture correctly, instead
+// of assuming it owns the whole backing texture — the same problem ebiten's driver.Region solves.
+type AuxPicture struct {
+	Picture Picture
+	Region  Rect
+}
+
+// NewShader creates a new Shader from the given vertex and fragment shader source.
+func NewShader(vertexSrc, fragmentSrc string) *Shader {
+	return &Shader{
+		vertexSrc:   vertexSrc,
+		fragmentSrc: fragmentSrc,
+		uniforms:    make(Uniforms),
+	}
+}
+
+// VertexSource returns the Shader's vertex shader source.
+func (s *Shader) VertexSource() string {
+	return s.vertexSrc
+}
+
+// FragmentSource returns the Shader's fragment shader source.
+func (s *Shader) FragmentSource() string {
+	return s.fragmentSrc
+}
+
+// SetUniform sets the value of the named uniform used by the Shader.
+func (s *Shader) SetUniform(name string, value interface{}) {
+	s.uniforms[name] = value
+	s.rev++
+}
+
+// Uniforms returns the Shader's current uniform values. The returned map must not be modified;
+// use SetUniform instead.
+func (s *Shader) Uniforms() Uniforms {
+	return s.uniforms
+}
+
+// SetAux binds pic to the given auxiliary sampler slot, using pic.Bounds() as the region a
+// backend should sample it through.
+//
+// Slot 0 is always occupied by the Picture being drawn; auxiliary Pictures occupy slots 1..N-1.
+// SetAux panics if slot is not positive.
+func (s *Shader) SetAux(slot int, pic Picture) {
+	if slot <= 0 {
+		panic(fmt.Errorf("Shader.SetAux: invalid auxiliary slot %d", slot))
+	}
+	for len(s.aux) < slot {
+		s.aux = append(s.aux, AuxPicture{})
+	}
+	s.aux[slot-1] = AuxPicture{Picture: pic, Region: pic.Bounds()}
+	s.rev++
+}
+
+// Aux returns the AuxPictures currently bound to auxiliary sampler slots 1..N-1, in order. A zero
+// AuxPicture (nil Picture) means that slot is unbound.
+func (s *Shader) Aux() []AuxPicture {
+	return s.aux
+}